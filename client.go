@@ -2,54 +2,254 @@ package otelsetup
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/jaeger"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
+// Exporter selects which span exporter InitTracer wires up.
+type Exporter string
+
+const (
+	ExporterOTLPGRPC Exporter = "otlpgrpc"
+	ExporterOTLPHTTP Exporter = "otlphttp"
+	ExporterStdout   Exporter = "stdout"
+	ExporterJaeger   Exporter = "jaeger"
+	ExporterNone     Exporter = "none"
+)
+
+// Sampler selects which sdktrace.Sampler InitTracer configures.
+type Sampler string
+
+const (
+	SamplerAlways           Sampler = "always"
+	SamplerNever            Sampler = "never"
+	SamplerRatio            Sampler = "ratio"
+	SamplerParentBasedRatio Sampler = "parentbased_ratio"
+)
+
+// closeStageTimeout bounds each individual stage of TracerProvider.Close
+// (flush, shutdown, conn close) so a hung exporter can't block shutdown
+// indefinitely.
+const closeStageTimeout = 10 * time.Second
+
 type Config struct {
 	Endpoint    string
 	Insecure    bool
 	ServiceName string
+	Environment string
+
+	// ServiceNamespace and ServiceInstanceID, when set, are added to the
+	// resource as service.namespace and service.instance.id.
+	ServiceNamespace  string
+	ServiceInstanceID string
+
+	// ResourceAttributes are merged onto the resource alongside the
+	// service.* and deployment.environment attributes above.
+	ResourceAttributes map[string]string
+
+	Exporter Exporter
+	Sampler  Sampler
+	// SampleRatio is used when Sampler is SamplerRatio or SamplerParentBasedRatio.
+	SampleRatio float64
+
+	// Batcher tuning, applied via sdktrace.BatchSpanProcessorOption.
+	MaxQueueSize       int
+	BatchTimeout       time.Duration
+	MaxExportBatchSize int
+
+	// Logger receives SDK-internal errors (export failures, dropped spans)
+	// instead of them going to the stdlib log package.
+	Logger *log.Logger
 }
 
-func InitTracer(cfg Config) func() {
+// TracerProvider wraps the installed *sdktrace.TracerProvider together with
+// the gRPC connection backing an OTLP exporter (nil for other exporters), so
+// an application can drain traces cleanly on shutdown instead of losing them
+// to os.Exit.
+type TracerProvider struct {
+	tp   *sdktrace.TracerProvider
+	conn *grpc.ClientConn
+}
+
+// InitTracer builds and installs a global TracerProvider for cfg, returning
+// a *TracerProvider for graceful shutdown and any error encountered while
+// wiring up the exporter or resource - callers decide whether to fall back
+// to a no-op provider rather than the init itself calling log.Fatalf.
+func InitTracer(cfg Config) (*TracerProvider, error) {
 	ctx := context.Background()
-	options := []otlptracegrpc.Option{}
-	if cfg.Endpoint != "" {
-		options = append(options, otlptracegrpc.WithEndpoint(cfg.Endpoint))
-	}
-	if cfg.Insecure {
-		options = append(options, otlptracegrpc.WithInsecure())
-	}
 
-	exporter, err := otlptracegrpc.New(ctx, options...)
+	exporter, conn, err := newSpanExporter(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to create exporter: %v", err)
+		return nil, fmt.Errorf("create exporter: %w", err)
 	}
 
-	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(cfg.ServiceName)))
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(cfg.ServiceName)}
+	if cfg.Environment != "" {
+		attrs = append(attrs, semconv.DeploymentEnvironmentKey.String(cfg.Environment))
+	}
+	if cfg.ServiceNamespace != "" {
+		attrs = append(attrs, semconv.ServiceNamespaceKey.String(cfg.ServiceNamespace))
+	}
+	if cfg.ServiceInstanceID != "" {
+		attrs = append(attrs, semconv.ServiceInstanceIDKey.String(cfg.ServiceInstanceID))
+	}
+	for k, v := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	res, err := resource.New(ctx, resource.WithAttributes(attrs...))
 	if err != nil {
-		log.Fatalf("failed to create resource: %v", err)
+		if conn != nil {
+			conn.Close()
+		}
+		return nil, fmt.Errorf("create resource: %w", err)
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
+	tpOpts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sdktrace.AlwaysSample()), // Có thể config tùy môi trường
-	)
+		sdktrace.WithSampler(newSampler(cfg)),
+	}
+	if exporter != nil {
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exporter, batchOptions(cfg)...))
+	}
+
+	tp := sdktrace.NewTracerProvider(tpOpts...)
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = log.New(os.Stderr, "", log.LstdFlags)
+	}
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Printf("otel: %v", err)
+	}))
 
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
-	return func() {
-		if err := tp.Shutdown(ctx); err != nil {
-			log.Printf("error shutting down tracer provider: %v", err)
+	return &TracerProvider{tp: tp, conn: conn}, nil
+}
+
+// Close force-flushes pending spans, shuts down the SDK provider, and closes
+// the backing gRPC connection (if any). Each stage is bounded by its own
+// closeStageTimeout and errors from all stages are aggregated, so a slow or
+// failing exporter doesn't prevent the others from running.
+func (p *TracerProvider) Close(ctx context.Context) error {
+	var errs []error
+
+	flushCtx, cancel := context.WithTimeout(ctx, closeStageTimeout)
+	if err := p.tp.ForceFlush(flushCtx); err != nil {
+		errs = append(errs, fmt.Errorf("flush spans: %w", err))
+	}
+	cancel()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, closeStageTimeout)
+	if err := p.tp.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("shutdown provider: %w", err))
+	}
+	cancel()
+
+	if p.conn != nil {
+		closeCtx, cancel := context.WithTimeout(ctx, closeStageTimeout)
+		closed := make(chan error, 1)
+		go func() { closed <- p.conn.Close() }()
+		select {
+		case err := <-closed:
+			if err != nil {
+				errs = append(errs, fmt.Errorf("close exporter conn: %w", err))
+			}
+		case <-closeCtx.Done():
+			errs = append(errs, fmt.Errorf("close exporter conn: %w", closeCtx.Err()))
+		}
+		cancel()
+	}
+
+	return errors.Join(errs...)
+}
+
+func newSpanExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, *grpc.ClientConn, error) {
+	switch cfg.Exporter {
+	case "", ExporterOTLPGRPC:
+		dialOptions := []grpc.DialOption{}
+		if cfg.Insecure {
+			dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		} else {
+			dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
 		}
+		conn, err := grpc.NewClient(cfg.Endpoint, dialOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithGRPCConn(conn))
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		return exporter, conn, nil
+	case ExporterOTLPHTTP:
+		options := []otlptracehttp.Option{}
+		if cfg.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, options...)
+		return exporter, nil, err
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterJaeger:
+		exporter, err := jaeger.New(jaeger.WithCollectorEndpoint(jaeger.WithEndpoint(cfg.Endpoint)))
+		return exporter, nil, err
+	case ExporterNone:
+		return nil, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("otelsetup: unknown exporter %q", cfg.Exporter)
+	}
+}
+
+func newSampler(cfg Config) sdktrace.Sampler {
+	switch cfg.Sampler {
+	case "", SamplerAlways:
+		return sdktrace.AlwaysSample()
+	case SamplerNever:
+		return sdktrace.NeverSample()
+	case SamplerRatio:
+		return sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	case SamplerParentBasedRatio:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))
+	default:
+		return sdktrace.AlwaysSample()
+	}
+}
+
+func batchOptions(cfg Config) []sdktrace.BatchSpanProcessorOption {
+	var opts []sdktrace.BatchSpanProcessorOption
+	if cfg.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(cfg.MaxQueueSize))
+	}
+	if cfg.BatchTimeout > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(cfg.BatchTimeout))
+	}
+	if cfg.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(cfg.MaxExportBatchSize))
 	}
+	return opts
 }
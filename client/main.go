@@ -19,6 +19,8 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/tandatle266/gotel/propagation"
 )
 
 const (
@@ -35,6 +37,11 @@ type Client struct {
 }
 
 func NewClient() *Client {
+	// Install the composite W3C/baggage propagator so the correlation ID
+	// RunComprehensiveTest seeds carries across both the HTTP and gRPC hops
+	// it makes.
+	propagation.Init(false)
+
 	// Initialize tracing
 	shutdown := tracing.InitTracer(tracing.Config{
 		ServiceName: serviceName,
@@ -62,6 +69,8 @@ func NewClient() *Client {
 	// Create gRPC connection with tracing
 	dialOptions := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(propagation.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(propagation.StreamClientInterceptor()),
 	}
 	dialOptions = append(dialOptions, tracing.ClientOptions()...)
 
@@ -256,6 +265,13 @@ func (c *Client) TestGRPCEndpoints(ctx context.Context) {
 
 // Comprehensive test with distributed tracing
 func (c *Client) RunComprehensiveTest(ctx context.Context) {
+	// Seed a correlation ID here, since this test originates the request
+	// chain rather than relaying one from an inbound request. makeHTTPRequest
+	// carries it over HTTP via baggage (through otelhttp's transport), and
+	// the gRPC dial's propagation interceptors carry it over gRPC, so every
+	// hop below shares one ID.
+	ctx = propagation.NewCorrelationID(ctx)
+
 	ctx, span := c.tracer.Start(ctx, "comprehensive_test")
 	defer span.End()
 
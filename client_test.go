@@ -0,0 +1,26 @@
+package otelsetup
+
+import (
+	"context"
+	"testing"
+)
+
+// TestNewSpanExporterSecureDefault guards against the secure (Insecure:
+// false) OTLP gRPC path regressing back to passing grpc.NewClient no
+// transport credentials at all, which fails every call synchronously with
+// errNoTransportSecurity instead of dialing with TLS.
+func TestNewSpanExporterSecureDefault(t *testing.T) {
+	ctx := context.Background()
+	cfg := Config{Endpoint: "127.0.0.1:4317"}
+
+	exporter, conn, err := newSpanExporter(ctx, cfg)
+	if err != nil {
+		t.Fatalf("newSpanExporter with Insecure=false: %v", err)
+	}
+	defer conn.Close()
+	defer exporter.Shutdown(ctx)
+
+	if conn == nil {
+		t.Fatal("expected a non-nil grpc.ClientConn for the OTLP gRPC exporter")
+	}
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewTracedBunDB wraps bunDB with a bun.QueryHook that starts a span for
+// every query, reporting the same db.* attributes as the GORM tracing
+// plugin. dbName is the database bunDB was opened against (bun, unlike
+// gorm.Open, doesn't take a DatabaseConfig itself, so the caller supplies
+// it) and is reported as db.name.
+func NewTracedBunDB(bunDB *bun.DB, serviceName, dbName string) *bun.DB {
+	bunDB.AddQueryHook(&bunQueryHook{
+		tracer: otel.Tracer(serviceName),
+		system: bunDB.Dialect().Name().String(),
+		dbName: dbName,
+	})
+	return bunDB
+}
+
+type bunQueryHook struct {
+	tracer trace.Tracer
+	system string
+	dbName string
+}
+
+func (h *bunQueryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	ctx, _ = h.tracer.Start(ctx, "db."+string(event.Operation()),
+		trace.WithSpanKind(trace.SpanKindClient),
+	)
+	return ctx
+}
+
+func (h *bunQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.system", h.system),
+		attribute.String("db.name", h.dbName),
+		attribute.String("db.operation", string(event.Operation())),
+		attribute.String("db.statement", event.Query),
+	)
+	if event.IQuery != nil {
+		if table := event.IQuery.GetTableName(); table != "" {
+			span.SetAttributes(attribute.String("db.table", table))
+		}
+	}
+
+	if event.Result != nil {
+		if rows, err := event.Result.RowsAffected(); err == nil {
+			span.SetAttributes(attribute.Int64("db.rows_affected", rows))
+		}
+	}
+
+	if event.Err != nil {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+}
@@ -0,0 +1,15 @@
+package db
+
+// DatabaseConfig describes how to connect to the backing SQL database.
+//
+// Driver selects the GORM dialector used to open the connection; currently
+// "postgres" (the default when empty) and "mysql" are supported.
+type DatabaseConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	DBName   string
+	Schema   string
+	Driver   string
+}
@@ -0,0 +1,154 @@
+package db
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "gotel:span"
+
+// NewTracedDatabase opens a GORM connection for cfg and registers a plugin
+// that starts a span for every statement the connection executes. serviceName
+// is used as the tracer name; db.system is reported from the dialector's name.
+func NewTracedDatabase(cfg DatabaseConfig, serviceName string) (*gorm.DB, error) {
+	dialector, err := newDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	gormDB, err := gorm.Open(dialector)
+	if err != nil {
+		return nil, fmt.Errorf("open database: %w", err)
+	}
+
+	if err := gormDB.Use(newTracingPlugin(serviceName, cfg)); err != nil {
+		return nil, fmt.Errorf("register tracing plugin: %w", err)
+	}
+
+	return gormDB, nil
+}
+
+func newDialector(cfg DatabaseConfig) (gorm.Dialector, error) {
+	switch cfg.Driver {
+	case "", "postgres":
+		dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+			cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName)
+		if cfg.Schema != "" {
+			dsn += " search_path=" + cfg.Schema
+		}
+		return postgres.Open(dsn), nil
+	case "mysql":
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true",
+			cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.DBName)
+		return mysql.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("db: unsupported driver %q", cfg.Driver)
+	}
+}
+
+// tracingPlugin implements gorm.Plugin and wraps every GORM callback phase
+// (create, query, update, delete, row, raw) in a span.
+type tracingPlugin struct {
+	tracer trace.Tracer
+	dbName string
+}
+
+func newTracingPlugin(serviceName string, cfg DatabaseConfig) *tracingPlugin {
+	return &tracingPlugin{
+		tracer: otel.Tracer(serviceName),
+		dbName: cfg.DBName,
+	}
+}
+
+func (p *tracingPlugin) Name() string {
+	return "gotel:tracing"
+}
+
+func (p *tracingPlugin) Initialize(gormDB *gorm.DB) error {
+	if err := gormDB.Callback().Create().Before("gorm:create").Register("gotel:before_create", p.before("create")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Create().After("gorm:create").Register("gotel:after_create", p.after("create")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Query().Before("gorm:query").Register("gotel:before_query", p.before("query")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Query().After("gorm:query").Register("gotel:after_query", p.after("query")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Update().Before("gorm:update").Register("gotel:before_update", p.before("update")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Update().After("gorm:update").Register("gotel:after_update", p.after("update")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Delete().Before("gorm:delete").Register("gotel:before_delete", p.before("delete")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Delete().After("gorm:delete").Register("gotel:after_delete", p.after("delete")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Row().Before("gorm:row").Register("gotel:before_row", p.before("row")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Row().After("gorm:row").Register("gotel:after_row", p.after("row")); err != nil {
+		return err
+	}
+	if err := gormDB.Callback().Raw().Before("gorm:raw").Register("gotel:before_raw", p.before("raw")); err != nil {
+		return err
+	}
+	return gormDB.Callback().Raw().After("gorm:raw").Register("gotel:after_raw", p.after("raw"))
+}
+
+func (p *tracingPlugin) before(operation string) func(*gorm.DB) {
+	return func(gormDB *gorm.DB) {
+		ctx, span := p.tracer.Start(gormDB.Statement.Context, "db."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+		)
+		gormDB.Statement.Context = ctx
+		gormDB.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func (p *tracingPlugin) after(operation string) func(*gorm.DB) {
+	return func(gormDB *gorm.DB) {
+		value, ok := gormDB.InstanceGet(gormSpanKey)
+		if !ok {
+			return
+		}
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		span.SetAttributes(
+			attribute.String("db.system", dialectorName(gormDB)),
+			attribute.String("db.name", p.dbName),
+			attribute.String("db.operation", operation),
+			attribute.String("db.table", gormDB.Statement.Table),
+			attribute.String("db.statement", gormDB.Statement.SQL.String()),
+			attribute.Int64("db.rows_affected", gormDB.Statement.RowsAffected),
+		)
+
+		if err := gormDB.Error; err != nil && err != gorm.ErrRecordNotFound {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+}
+
+func dialectorName(gormDB *gorm.DB) string {
+	if gormDB.Dialector == nil {
+		return ""
+	}
+	return gormDB.Dialector.Name()
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
-	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -22,11 +21,20 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+
+	otelsetup "github.com/tandatle266/gotel"
+	gotelDB "github.com/tandatle266/gotel/db"
+	gotelGrpc "github.com/tandatle266/gotel/grpc"
+	gotelMetrics "github.com/tandatle266/gotel/metrics"
+	gotelMiddleware "github.com/tandatle266/gotel/middleware"
+	"github.com/tandatle266/gotel/propagation"
+	gotelRedis "github.com/tandatle266/gotel/redis"
 )
 
 const (
-	httpPort = ":8080"
-	grpcPort = ":9090"
+	httpPort    = ":8080"
+	grpcPort    = ":9090"
+	metricsPort = ":9100"
 	serviceName = "gotel-example-server"
 )
 
@@ -41,32 +49,52 @@ type User struct {
 // Server struct contains all dependencies
 type Server struct {
 	db     *gorm.DB
-	redis  *redis.Client
+	redis  redis.UniversalClient
 	tracer trace.Tracer
 }
 
 // NewServer creates a new server instance
 func NewServer() *Server {
+	// Install the composite W3C/baggage propagator so trace context and the
+	// correlation ID baggage member set by propagation.HTTPMiddleware and
+	// propagation.*ServerInterceptor carry across HTTP and gRPC hops alike.
+	propagation.Init(false)
+
+	// Metrics on a separate admin port, so scraping never competes with
+	// request traffic on httpPort/grpcPort.
+	if _, err := gotelMetrics.InitMeterProvider(gotelMetrics.Config{
+		ServiceName: serviceName,
+		Exporter:    gotelMetrics.ExporterPrometheus,
+	}); err != nil {
+		log.Printf("⚠️  Metrics init failed: %v", err)
+	} else {
+		gotelMetrics.ServeObservability(metricsPort, true)
+		log.Printf("📈 Metrics: http://localhost%s/metrics", metricsPort)
+	}
+
 	// Initialize tracing
-	shutdown := tracing.InitTracer(tracing.Config{
+	tp, err := otelsetup.InitTracer(otelsetup.Config{
 		ServiceName: serviceName,
 		Endpoint:    "localhost:4317",
 		Insecure:    true,
 		Environment: "development",
 	})
-	
+	if err != nil {
+		log.Fatalf("Failed to init tracer: %v", err)
+	}
+
 	// Setup graceful shutdown
 	go func() {
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 		<-c
 		log.Println("🛑 Shutting down...")
-		shutdown()
+		tp.Close(context.Background())
 		os.Exit(0)
 	}()
 
 	// Initialize database with tracing
-	db, err := tracing.NewTracedDatabase(tracing.DatabaseConfig{
+	db, err := gotelDB.NewTracedDatabase(gotelDB.DatabaseConfig{
 		Host:     "localhost",
 		Port:     "5432",
 		User:     "gotel",
@@ -84,15 +112,14 @@ func NewServer() *Server {
 	}
 
 	// Initialize Redis with tracing
-	redisClient := tracing.NewTracedRedisClient(tracing.RedisConfig{
-		Host:     "localhost",
-		Port:     "6379",
-		Password: "",
-		DB:       0,
-	}, serviceName)
-
-	// Test Redis connection
-	if err := redisClient.Ping(context.Background()).Err(); err != nil {
+	redisClient, err := gotelRedis.NewTracedRedisUniversalClient(gotelRedis.RedisConfig{
+		Mode:  gotelRedis.ModeStandalone,
+		Addrs: []string{"localhost:6379"},
+	})
+	if err != nil {
+		log.Printf("⚠️  Redis connection failed: %v", err)
+		log.Println("💡 Make sure Redis is running: docker-compose up redis")
+	} else if err := redisClient.Ping(context.Background()).Err(); err != nil {
 		log.Printf("⚠️  Redis connection failed: %v", err)
 		log.Println("💡 Make sure Redis is running: docker-compose up redis")
 	} else {
@@ -106,13 +133,26 @@ func NewServer() *Server {
 	}
 }
 
+// ginWrapHTTPMiddleware adapts a standard net/http middleware (one that
+// takes and returns http.Handler) into gin middleware, so it can sit in the
+// same router.Use chain as gin-native handlers like gin.Recovery.
+func ginWrapHTTPMiddleware(mw func(http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Request = r
+			c.Next()
+		})).ServeHTTP(c.Writer, c.Request)
+	}
+}
+
 // HTTP Handlers
 func (s *Server) setupHTTPRoutes() *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	
 	// Add tracing middleware
-	router.Use(tracing.HTTPMiddleware(serviceName))
+	router.Use(gotelMiddleware.GinMiddleware(serviceName)...)
+	router.Use(ginWrapHTTPMiddleware(propagation.HTTPMiddleware))
 	router.Use(gin.Recovery())
 	
 	// Health check endpoint
@@ -422,18 +462,27 @@ func main() {
 		Handler: httpRouter,
 	}
 	
-	// Setup gRPC server
-	grpcServer := grpc.NewServer(tracing.ServerOptions()...)
-	proto.RegisterExampleServiceServer(grpcServer, server)
-	
-	lis, err := net.Listen("tcp", grpcPort)
+	// Setup gRPC server. NewServerWithTracing installs the otelgrpc stats
+	// handler, health, and reflection, and gives us Start/Stop instead of
+	// the net.Listen/grpc.NewServer/Serve/GracefulStop this used to hand-roll.
+	grpcServer, err := gotelGrpc.NewServerWithTracing(grpcPort, func(gs *grpc.Server) {
+		proto.RegisterExampleServiceServer(gs, server)
+	}, gotelGrpc.WithServerOptions(
+		grpc.ChainUnaryInterceptor(propagation.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(propagation.StreamServerInterceptor()),
+	))
 	if err != nil {
-		log.Fatalf("Failed to listen on gRPC port: %v", err)
+		log.Fatalf("Failed to build gRPC server: %v", err)
 	}
-	
+
+	grpcCtx, cancelGRPC := context.WithCancel(context.Background())
+	if err := grpcServer.Start(grpcCtx); err != nil {
+		log.Fatalf("Failed to start gRPC server: %v", err)
+	}
+
 	// Start servers
 	var wg sync.WaitGroup
-	
+
 	// Start HTTP server
 	wg.Add(1)
 	go func() {
@@ -445,35 +494,29 @@ func main() {
 			log.Fatalf("HTTP server error: %v", err)
 		}
 	}()
-	
-	// Start gRPC server
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		log.Printf("🔌 gRPC server starting on %s", grpcPort)
-		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("gRPC server error: %v", err)
-		}
-	}()
-	
+
+	log.Printf("🔌 gRPC server starting on %s", grpcPort)
 	log.Println("✅ All servers started successfully!")
 	log.Println("📈 View traces at: http://localhost:16686")
 	log.Println("🛑 Press Ctrl+C to shutdown")
-	
+
 	// Wait for shutdown signal
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	<-c
-	
+
 	log.Println("🛑 Shutting down servers...")
-	
+
 	// Graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
-	
-	httpServer.Shutdown(ctx)
-	grpcServer.GracefulStop()
-	
+
+	httpServer.Shutdown(shutdownCtx)
+	cancelGRPC()
+	if err := grpcServer.Stop(shutdownCtx); err != nil {
+		log.Printf("gRPC shutdown error: %v", err)
+	}
+
 	wg.Wait()
 	log.Println("👋 Server shutdown complete")
 }
\ No newline at end of file
@@ -0,0 +1,41 @@
+package interceptors
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor unwraps a returned *status.Status back into the
+// typed Go error reg maps it to, so callers can errors.Is(err, ErrNotFound)
+// (or ErrAlreadyExists, ErrPermissionDenied) transparently across the
+// process boundary.
+func UnaryClientInterceptor(reg *Registry) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		return unwrapStatus(reg, err)
+	}
+}
+
+// StreamClientInterceptor is the streaming equivalent of UnaryClientInterceptor.
+func StreamClientInterceptor(reg *Registry) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, unwrapStatus(reg, err)
+		}
+		return stream, nil
+	}
+}
+
+func unwrapStatus(reg *Registry, err error) error {
+	if err == nil {
+		return nil
+	}
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return reg.FromStatus(st)
+}
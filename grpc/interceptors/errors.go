@@ -0,0 +1,57 @@
+// Package interceptors provides gRPC interceptors that translate domain
+// errors to gRPC status codes on the server side, and back into typed Go
+// errors on the client side, so callers can errors.Is a sentinel across a
+// process boundary.
+package interceptors
+
+// NotFoundError, AlreadyExistsError and PermissionDeniedError are sentinel
+// error types the default Registry maps to codes.NotFound, codes.AlreadyExists
+// and codes.PermissionDenied respectively. Construct them with NotFound,
+// AlreadyExists and PermissionDenied below. Each implements Is(error) bool
+// by type rather than message, so errors.Is(err, ErrNotFound) matches any
+// *NotFoundError FromStatus reconstructs on the client side, regardless of
+// the message the server sent.
+type NotFoundError struct{ msg string }
+
+// ErrNotFound is a sentinel for errors.Is(err, ErrNotFound); its message is
+// irrelevant since NotFoundError.Is matches by type.
+var ErrNotFound = &NotFoundError{}
+
+func NotFound(msg string) error { return &NotFoundError{msg: msg} }
+
+func (e *NotFoundError) Error() string { return e.msg }
+
+func (e *NotFoundError) Is(target error) bool {
+	_, ok := target.(*NotFoundError)
+	return ok
+}
+
+type AlreadyExistsError struct{ msg string }
+
+// ErrAlreadyExists is a sentinel for errors.Is(err, ErrAlreadyExists); its
+// message is irrelevant since AlreadyExistsError.Is matches by type.
+var ErrAlreadyExists = &AlreadyExistsError{}
+
+func AlreadyExists(msg string) error { return &AlreadyExistsError{msg: msg} }
+
+func (e *AlreadyExistsError) Error() string { return e.msg }
+
+func (e *AlreadyExistsError) Is(target error) bool {
+	_, ok := target.(*AlreadyExistsError)
+	return ok
+}
+
+type PermissionDeniedError struct{ msg string }
+
+// ErrPermissionDenied is a sentinel for errors.Is(err, ErrPermissionDenied);
+// its message is irrelevant since PermissionDeniedError.Is matches by type.
+var ErrPermissionDenied = &PermissionDeniedError{}
+
+func PermissionDenied(msg string) error { return &PermissionDeniedError{msg: msg} }
+
+func (e *PermissionDeniedError) Error() string { return e.msg }
+
+func (e *PermissionDeniedError) Is(target error) bool {
+	_, ok := target.(*PermissionDeniedError)
+	return ok
+}
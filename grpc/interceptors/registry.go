@@ -0,0 +1,82 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Registry maps domain errors to gRPC codes (server side) and back into
+// typed Go errors (client side). Applications register their own mappings
+// alongside the defaults with Register.
+type Registry struct {
+	entries []registryEntry
+}
+
+type registryEntry struct {
+	code    codes.Code
+	matches func(error) bool
+	rebuild func(msg string) error
+}
+
+// NewRegistry returns a Registry pre-populated with the mappings every
+// gRPC service needs: context cancellation/deadlines, missing files, and the
+// NotFound/AlreadyExists/PermissionDenied sentinels declared in errors.go.
+func NewRegistry() *Registry {
+	r := &Registry{}
+	r.Register(codes.Canceled, func(err error) bool { return errors.Is(err, context.Canceled) }, nil)
+	r.Register(codes.DeadlineExceeded, func(err error) bool { return errors.Is(err, context.DeadlineExceeded) }, nil)
+	r.Register(codes.NotFound, func(err error) bool { return errors.Is(err, os.ErrNotExist) }, nil)
+	r.Register(codes.NotFound, isType[*NotFoundError], func(msg string) error { return NotFound(msg) })
+	r.Register(codes.AlreadyExists, isType[*AlreadyExistsError], func(msg string) error { return AlreadyExists(msg) })
+	r.Register(codes.PermissionDenied, isType[*PermissionDeniedError], func(msg string) error { return PermissionDenied(msg) })
+	return r
+}
+
+// Register adds a mapping between a domain error (recognized by matches) and
+// code. rebuild reconstructs the typed error from a status message on the
+// client side; pass nil if the mapping is server-to-code only (e.g. stdlib
+// sentinels with no client-side equivalent worth reconstructing).
+func (r *Registry) Register(code codes.Code, matches func(error) bool, rebuild func(msg string) error) {
+	r.entries = append(r.entries, registryEntry{code: code, matches: matches, rebuild: rebuild})
+}
+
+// ToStatus converts err into a *status.Status using the first matching
+// registered mapping, defaulting to codes.Unknown.
+func (r *Registry) ToStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	for _, entry := range r.entries {
+		if entry.matches(err) {
+			return status.New(entry.code, err.Error())
+		}
+	}
+	return status.New(codes.Unknown, err.Error())
+}
+
+// FromStatus reconstructs a typed Go error from st using the first
+// registered mapping with a non-nil rebuild func for st.Code(), falling back
+// to the plain *status.Error.
+func (r *Registry) FromStatus(st *status.Status) error {
+	for _, entry := range r.entries {
+		if entry.code == st.Code() && entry.rebuild != nil {
+			return entry.rebuild(st.Message())
+		}
+	}
+	return st.Err()
+}
+
+func isType[T error](err error) bool {
+	var target T
+	return errors.As(err, &target)
+}
+
+// DefaultRegistry is the Registry that the gotel/grpc helpers
+// (InstrumentGRPCServerOptions, InstrumentGRPCDialOptions and
+// NewClientWithTracing) pass to the interceptors in this package. The
+// interceptors themselves always take an explicit Registry.
+var DefaultRegistry = NewRegistry()
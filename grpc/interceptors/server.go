@@ -0,0 +1,48 @@
+package interceptors
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// UnaryServerInterceptor converts any non-nil error returned by the handler
+// into a status.Error using reg, and records the resulting code on the
+// active span so traces show the semantic error.
+func UnaryServerInterceptor(reg *Registry) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, nil
+		}
+
+		st := reg.ToStatus(err)
+		recordStatusCode(ctx, st.Code())
+		return resp, st.Err()
+	}
+}
+
+// StreamServerInterceptor is the streaming equivalent of UnaryServerInterceptor.
+func StreamServerInterceptor(reg *Registry) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err == nil {
+			return nil
+		}
+
+		st := reg.ToStatus(err)
+		recordStatusCode(ss.Context(), st.Code())
+		return st.Err()
+	}
+}
+
+func recordStatusCode(ctx context.Context, code codes.Code) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+}
@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// Server wraps *grpc.Server and separates listener creation from serving, so
+// a caller can bind its port (and fail fast on a bad address) before
+// starting any other goroutines.
+type Server struct {
+	grpcServer   *grpc.Server
+	drainTimeout time.Duration
+
+	// addr, lis, cancel, and runErr are only set when the Server was built
+	// via NewServerWithTracing, for use by Start/Stop.
+	addr   string
+	lis    net.Listener
+	cancel context.CancelFunc
+	runErr chan error
+}
+
+// NewServer builds a Server with opts already passed through
+// InstrumentGRPCServerOptions by the caller.
+func NewServer(opts ...grpc.ServerOption) *Server {
+	return &Server{
+		grpcServer:   grpc.NewServer(opts...),
+		drainTimeout: defaultDrainTimeout,
+	}
+}
+
+// GRPCServer returns the underlying *grpc.Server so callers can register
+// services before calling Run.
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.grpcServer
+}
+
+// SetDrainTimeout overrides how long Run waits for in-flight RPCs to finish
+// during graceful shutdown before forcing the server to stop.
+func (s *Server) SetDrainTimeout(d time.Duration) {
+	s.drainTimeout = d
+}
+
+// Listen binds addr and returns the listener for Run, reporting bind errors
+// synchronously instead of inside a goroutine.
+func (s *Server) Listen(addr string) (net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("grpc: listen on %s: %w", addr, err)
+	}
+	return lis, nil
+}
+
+// Run serves on lis until ctx is done, then performs a graceful stop bounded
+// by the server's drain timeout, forcing a hard stop if it's exceeded.
+func (s *Server) Run(ctx context.Context, lis net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- s.grpcServer.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-time.After(s.drainTimeout):
+		s.grpcServer.Stop()
+		return ctx.Err()
+	}
+}
+
@@ -4,41 +4,83 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/tandatle266/gotel/grpc/interceptors"
 )
 
 type ClientFactory[T any] func(cc grpc.ClientConnInterface) T
 
-// NewClientWithTracing create a gRPC client with tracing
-func NewClientWithTracing[T any](addr string, factory ClientFactory[T]) (client T, conn *grpc.ClientConn, err error) {
-    dialOptions := []grpc.DialOption{
-        grpc.WithTransportCredentials(insecure.NewCredentials()),
-        grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
-    }
+// ClientOption configures NewClientWithTracing.
+type ClientOption func(*clientConfig)
 
-    conn, err = grpc.Dial(addr, dialOptions...)
-    if err != nil {
-        return client, nil, err
-    }
+type clientConfig struct {
+	security *SecurityConfig
+}
 
-    client = factory(conn)
-    return client, conn, nil
+// WithClientSecurity enables TLS, mTLS, or token-based auth on the client
+// NewClientWithTracing dials. Without it, the client dials insecurely.
+func WithClientSecurity(cfg *SecurityConfig) ClientOption {
+	return func(c *clientConfig) {
+		c.security = cfg
+	}
 }
 
-func InstrumentGRPCServerOptions(opts ...grpc.ServerOption) []grpc.ServerOption {
-    newOpts := make([]grpc.ServerOption, 0, len(opts)+1)
-    newOpts = append(newOpts, grpc.StatsHandler(otelgrpc.NewServerHandler()))
-    newOpts = append(newOpts, opts...)
-    return newOpts
+// NewClientWithTracing creates a gRPC client with tracing. It dials
+// insecurely by default, matching the previous behavior; pass
+// WithClientSecurity to enable TLS, mTLS, or token-based auth.
+func NewClientWithTracing[T any](addr string, factory ClientFactory[T], opts ...ClientOption) (client T, conn *grpc.ClientConn, err error) {
+	cfg := &clientConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var dialOptions []grpc.DialOption
+	if cfg.security != nil {
+		secOptions, err := cfg.security.DialOptions()
+		if err != nil {
+			return client, nil, err
+		}
+		dialOptions = append(dialOptions, secOptions...)
+	} else {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	dialOptions = append(dialOptions,
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(interceptors.UnaryClientInterceptor(interceptors.DefaultRegistry)),
+		grpc.WithChainStreamInterceptor(interceptors.StreamClientInterceptor(interceptors.DefaultRegistry)),
+	)
+
+	conn, err = grpc.Dial(addr, dialOptions...)
+	if err != nil {
+		return client, nil, err
+	}
+
+	client = factory(conn)
+	return client, conn, nil
 }
 
+func InstrumentGRPCServerOptions(opts ...grpc.ServerOption) []grpc.ServerOption {
+	newOpts := make([]grpc.ServerOption, 0, len(opts)+3)
+	newOpts = append(newOpts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(interceptors.UnaryServerInterceptor(interceptors.DefaultRegistry)),
+		grpc.ChainStreamInterceptor(interceptors.StreamServerInterceptor(interceptors.DefaultRegistry)),
+	)
+	newOpts = append(newOpts, opts...)
+	return newOpts
+}
 
 func InstrumentGRPCDialOptions(opts ...grpc.DialOption) []grpc.DialOption {
-    newOpts := make([]grpc.DialOption, 0, len(opts)+1)
-    newOpts = append(newOpts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
-    newOpts = append(newOpts, opts...)
-    return newOpts
+	newOpts := make([]grpc.DialOption, 0, len(opts)+3)
+	newOpts = append(newOpts,
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(interceptors.UnaryClientInterceptor(interceptors.DefaultRegistry)),
+		grpc.WithChainStreamInterceptor(interceptors.StreamClientInterceptor(interceptors.DefaultRegistry)),
+	)
+	newOpts = append(newOpts, opts...)
+	return newOpts
 }
 
 func StatsHandlerOption() grpc.ServerOption {
-    return grpc.StatsHandler(otelgrpc.NewServerHandler())
+	return grpc.StatsHandler(otelgrpc.NewServerHandler())
 }
@@ -0,0 +1,71 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const defaultDrainTimeout = 30 * time.Second
+
+// Server wraps *http.Server and separates listener creation from serving,
+// mirroring grpc.Server so an application can bind every one of its ports
+// up front and report bind errors synchronously.
+type Server struct {
+	httpServer   *http.Server
+	drainTimeout time.Duration
+}
+
+// NewServer builds a Server around handler.
+func NewServer(handler http.Handler) *Server {
+	return &Server{
+		httpServer:   &http.Server{Handler: handler},
+		drainTimeout: defaultDrainTimeout,
+	}
+}
+
+// SetDrainTimeout overrides how long Run waits for in-flight requests to
+// finish during graceful shutdown before forcing the server closed.
+func (s *Server) SetDrainTimeout(d time.Duration) {
+	s.drainTimeout = d
+}
+
+// Listen binds addr and returns the listener for Run, reporting bind errors
+// synchronously instead of inside a goroutine.
+func (s *Server) Listen(addr string) (net.Listener, error) {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver: listen on %s: %w", addr, err)
+	}
+	return lis, nil
+}
+
+// Run serves on lis until ctx is done, then shuts down gracefully bounded by
+// the server's drain timeout, forcing a hard close if it's exceeded.
+func (s *Server) Run(ctx context.Context, lis net.Listener) error {
+	serveErr := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			serveErr <- err
+			return
+		}
+		serveErr <- nil
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.drainTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		s.httpServer.Close()
+		return ctx.Err()
+	}
+	return nil
+}
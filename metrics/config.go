@@ -0,0 +1,21 @@
+package metrics
+
+// Exporter selects which metric exporter InitMeterProvider wires up.
+type Exporter string
+
+const (
+	// ExporterPrometheus exposes metrics on a /metrics scrape endpoint via
+	// ServeObservability.
+	ExporterPrometheus Exporter = "prometheus"
+	// ExporterOTLP pushes metrics to an OTLP gRPC collector.
+	ExporterOTLP Exporter = "otlp"
+)
+
+type Config struct {
+	ServiceName string
+	Exporter    Exporter
+
+	// Endpoint and Insecure are only used when Exporter is ExporterOTLP.
+	Endpoint string
+	Insecure bool
+}
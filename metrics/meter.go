@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// InitMeterProvider builds and installs a global MeterProvider for cfg, and
+// starts the Go runtime collector against it. With ExporterPrometheus,
+// metrics are pulled by whatever server mounts the registered /metrics
+// handler - pair it with ServeObservability - and the process collector is
+// additionally registered directly against the default Prometheus registry
+// that handler reads from, since it isn't an OTel SDK instrument and has no
+// other way to reach the meter provider's reader. With ExporterOTLP, metrics
+// are pushed to an OTLP collector on a fixed interval and the process
+// collector is not started, since prometheus.DefaultRegisterer is never
+// scraped or exported on that path.
+func InitMeterProvider(cfg Config) (*metric.MeterProvider, error) {
+	ctx := context.Background()
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceNameKey.String(cfg.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %w", err)
+	}
+
+	reader, err := newMetricReader(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create metric reader: %w", err)
+	}
+
+	mp := metric.NewMeterProvider(
+		metric.WithResource(res),
+		metric.WithReader(reader),
+	)
+
+	otel.SetMeterProvider(mp)
+
+	if err := runtime.Start(runtime.WithMeterProvider(mp)); err != nil {
+		return nil, fmt.Errorf("start runtime collector: %w", err)
+	}
+	if cfg.Exporter == "" || cfg.Exporter == ExporterPrometheus {
+		if err := prometheus.DefaultRegisterer.Register(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{})); err != nil {
+			return nil, fmt.Errorf("start process collector: %w", err)
+		}
+	}
+
+	return mp, nil
+}
+
+func newMetricReader(ctx context.Context, cfg Config) (metric.Reader, error) {
+	switch cfg.Exporter {
+	case "", ExporterPrometheus:
+		return otelprometheus.New()
+	case ExporterOTLP:
+		options := []otlpmetricgrpc.Option{}
+		if cfg.Endpoint != "" {
+			options = append(options, otlpmetricgrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			options = append(options, otlpmetricgrpc.WithInsecure())
+		}
+		exporter, err := otlpmetricgrpc.New(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		return metric.NewPeriodicReader(exporter), nil
+	default:
+		return nil, fmt.Errorf("metrics: unknown exporter %q", cfg.Exporter)
+	}
+}
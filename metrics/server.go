@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ServeObservability starts an admin HTTP server on addr exposing /metrics
+// (Prometheus scrape format) and, when enablePprof is true, the
+// /debug/pprof/* profiling endpoints. It is meant to run on a port separate
+// from the application's own HTTP/gRPC listeners, so scraping or profiling
+// never competes with request traffic.
+func ServeObservability(addr string, enablePprof bool) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	if enablePprof {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+
+	go func() {
+		_ = srv.ListenAndServe()
+	}()
+
+	return srv
+}
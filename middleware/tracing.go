@@ -1,42 +1,242 @@
 package middleware
 
 import (
-	"fmt"
+	"bytes"
+	"io"
+	"mime"
 	"net/http"
+	"path"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
-func GinMiddleware(serviceName string) gin.HandlerFunc {
-	mw := otelgin.Middleware(serviceName,
+// Option configures GinMiddleware.
+type Option func(*ginConfig)
+
+type ginConfig struct {
+	ignorePaths             []string
+	routeSampler            func(*gin.Context) sdktrace.SamplingResult
+	requestBodyMax          int
+	requestBodyContentTypes []string
+	spanNameFormatter       func(r *http.Request) string
+	baggagePrefix           string
+}
+
+// defaultRequestBodyContentTypes is used by WithRequestBodyCapture when
+// WithRequestBodyContentTypes isn't also set: the content types worth
+// recording as readable text on a span.
+var defaultRequestBodyContentTypes = []string{"application/json", "text/*"}
+
+// WithIgnorePaths drops spans for requests whose path matches one of paths,
+// either exactly or as a path.Match glob (e.g. "/healthz", "/metrics/*").
+func WithIgnorePaths(paths []string) Option {
+	return func(cfg *ginConfig) {
+		cfg.ignorePaths = paths
+	}
+}
+
+// WithRouteSampler lets callers sample differently per route (e.g. a lower
+// ratio for high-traffic endpoints). Requests for which fn returns a Drop
+// decision are served without a span.
+func WithRouteSampler(fn func(*gin.Context) sdktrace.SamplingResult) Option {
+	return func(cfg *ginConfig) {
+		cfg.routeSampler = fn
+	}
+}
+
+// WithRequestBodyCapture records up to maxBytes of the request body as a
+// span attribute, for requests whose Content-Type matches
+// WithRequestBodyContentTypes (defaulting to "application/json" and
+// "text/*") - other content types, e.g. binary uploads, are never buffered.
+func WithRequestBodyCapture(maxBytes int) Option {
+	return func(cfg *ginConfig) {
+		cfg.requestBodyMax = maxBytes
+	}
+}
+
+// WithRequestBodyContentTypes restricts WithRequestBodyCapture to requests
+// whose Content-Type matches one of types, each either an exact media type
+// ("application/json") or a "prefix/*" wildcard ("text/*"). Overrides the
+// default of {"application/json", "text/*"}.
+func WithRequestBodyContentTypes(types ...string) Option {
+	return func(cfg *ginConfig) {
+		cfg.requestBodyContentTypes = types
+	}
+}
+
+// WithSpanNameFormatter overrides otelgin's default span name (the matched
+// route path).
+func WithSpanNameFormatter(fn func(r *http.Request) string) Option {
+	return func(cfg *ginConfig) {
+		cfg.spanNameFormatter = fn
+	}
+}
+
+// WithBaggagePrefix copies every baggage member onto the span as an
+// attribute named prefix+key. Defaults to "baggage.".
+func WithBaggagePrefix(prefix string) Option {
+	return func(cfg *ginConfig) {
+		cfg.baggagePrefix = prefix
+	}
+}
+
+// ginDroppedKey records shouldDrop's verdict in the gin.Context so the
+// second handler below, invoked via otelgin's own c.Next(), doesn't have to
+// recompute it.
+const ginDroppedKey = "gotel.middleware.dropped"
+
+// GinMiddleware returns the handler chain that instruments requests with
+// otelgin, then layers on trace-ID propagation, baggage-to-attribute
+// copying, request-body capture, and status recording. It must be
+// registered as a chain (engine.Use(GinMiddleware(name)...)), not a single
+// handler: otelgin's handler restores the pre-span request context via
+// defer as soon as it returns from c.Next(), so anything that reads the
+// span has to run from a handler otelgin's own c.Next() invokes - i.e. the
+// second handler here - rather than after the whole chain has returned.
+func GinMiddleware(serviceName string, opts ...Option) gin.HandlersChain {
+	cfg := &ginConfig{
+		baggagePrefix: "baggage.",
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if cfg.requestBodyMax > 0 && cfg.requestBodyContentTypes == nil {
+		cfg.requestBodyContentTypes = defaultRequestBodyContentTypes
+	}
+
+	mwOpts := []otelgin.Option{
 		otelgin.WithTracerProvider(otel.GetTracerProvider()),
-		otelgin.WithSpanStartOptions(
-			trace.WithAttributes(
-				attribute.String("service.name", serviceName),
-				attribute.String("env", "production"),
-			),
-		),
-		otelgin.WithSpanNameFormatter(func(c *gin.Context) string {
-			return fmt.Sprintf("%s %s", c.Request.Method, c.FullPath())
-		}),
+	}
+	if cfg.spanNameFormatter != nil {
+		mwOpts = append(mwOpts, otelgin.WithSpanNameFormatter(cfg.spanNameFormatter))
+	}
+	mw := otelgin.Middleware(serviceName, mwOpts...)
+
+	return gin.HandlersChain{
+		func(c *gin.Context) {
+			dropped := shouldDrop(c, cfg)
+			c.Set(ginDroppedKey, dropped)
+			if dropped {
+				c.Next()
+				return
+			}
+			mw(c)
+		},
+		func(c *gin.Context) {
+			if c.GetBool(ginDroppedKey) {
+				c.Next()
+				return
+			}
+
+			// Set before c.Next(): a header added after the handler has
+			// written its response is never sent.
+			if active := trace.SpanFromContext(c.Request.Context()); active.IsRecording() {
+				c.Header("X-Trace-Id", active.SpanContext().TraceID().String())
+			}
+
+			if cfg.requestBodyMax > 0 {
+				captureRequestBody(c, cfg.requestBodyMax, cfg.requestBodyContentTypes)
+			}
+
+			c.Next()
+
+			span := trace.SpanFromContext(c.Request.Context())
+			if !span.IsRecording() {
+				return
+			}
+
+			if c.Writer.Status() >= http.StatusBadRequest {
+				span.SetStatus(codes.Error, http.StatusText(c.Writer.Status()))
+				span.SetAttributes(attribute.Int("http.status_code", c.Writer.Status()))
+			}
+
+			for _, member := range baggage.FromContext(c.Request.Context()).Members() {
+				span.SetAttributes(attribute.String(cfg.baggagePrefix+member.Key(), member.Value()))
+			}
+		},
+	}
+}
+
+// shouldDrop reports whether the request should be served without a span,
+// either because its path is ignored or because the route sampler says so.
+func shouldDrop(c *gin.Context, cfg *ginConfig) bool {
+	reqPath := c.Request.URL.Path
+	for _, pattern := range cfg.ignorePaths {
+		if pattern == reqPath {
+			return true
+		}
+		if matched, err := path.Match(pattern, reqPath); err == nil && matched {
+			return true
+		}
+	}
+
+	if cfg.routeSampler != nil {
+		if result := cfg.routeSampler(c); result.Decision == sdktrace.Drop {
+			return true
+		}
+	}
+
+	return false
+}
+
+func captureRequestBody(c *gin.Context, maxBytes int, allowedContentTypes []string) {
+	if c.Request.Body == nil {
+		return
+	}
+	if !contentTypeAllowed(c.Request.Header.Get("Content-Type"), allowedContentTypes) {
+		return
+	}
+
+	limited := io.LimitReader(c.Request.Body, int64(maxBytes))
+	body, err := io.ReadAll(limited)
+	if err != nil {
+		return
+	}
+
+	// c.Request.Body's read position now sits just past the captured
+	// prefix, so splice it back in unread instead of buffering the
+	// remainder - a large request is never fully loaded into memory just to
+	// reconstruct it.
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+	span := trace.SpanFromContext(c.Request.Context())
+	span.SetAttributes(
+		attribute.String("http.request_body", string(body)),
+		attribute.Bool("http.request_body_truncated", len(body) == maxBytes),
 	)
+}
 
-	return func(c *gin.Context) {
-		mw(c)
+// contentTypeAllowed reports whether contentType (the raw Content-Type
+// header value) matches one of allowed, each either an exact media type or
+// a "prefix/*" wildcard.
+func contentTypeAllowed(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
 
-		span := trace.SpanFromContext(c.Request.Context())
-		if span.IsRecording() && c.Writer.Status() >= http.StatusBadRequest {
-			span.SetStatus(codes.Error, http.StatusText(c.Writer.Status()))
-			span.SetAttributes(attribute.Int("http.status_code", c.Writer.Status()))
+	for _, pattern := range allowed {
+		if prefix, ok := strings.CutSuffix(pattern, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if mediaType == pattern {
+			return true
 		}
 	}
+	return false
 }
 
 func InstrumentGinEngine(engine *gin.Engine, serviceName string) {
-    engine.Use(otelgin.Middleware(serviceName))
+	engine.Use(otelgin.Middleware(serviceName))
 }
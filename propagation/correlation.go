@@ -0,0 +1,51 @@
+package propagation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newCorrelationID returns a random 16-byte hex-encoded ID.
+func newCorrelationID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand.Read only fails if the system CSPRNG is broken; fall
+		// back to the zero ID rather than panicking mid-request.
+		return hex.EncodeToString(b)
+	}
+	return hex.EncodeToString(b)
+}
+
+// withCorrelationID injects id into ctx's baggage (so it propagates to
+// downstream hops through the composite propagator) and onto the active
+// span as the correlation_id attribute.
+func withCorrelationID(ctx context.Context, id string) context.Context {
+	member, err := baggage.NewMember(correlationIDSpanKey, id)
+	if err == nil {
+		if bag, err := baggage.FromContext(ctx).SetMember(member); err == nil {
+			ctx = baggage.ContextWithBaggage(ctx, bag)
+		}
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(correlationIDSpanKey, id))
+	return ctx
+}
+
+// CorrelationIDFromContext returns the correlation ID carried in ctx's
+// baggage, or "" if none is set.
+func CorrelationIDFromContext(ctx context.Context) string {
+	return baggage.FromContext(ctx).Member(correlationIDSpanKey).Value()
+}
+
+// NewCorrelationID seeds ctx with a freshly generated correlation ID. Use it
+// at the start of a request chain you originate (a CLI, a batch job, a test
+// harness) rather than relay from an inbound request, so every downstream
+// HTTP and gRPC hop attributed to it shares one ID.
+func NewCorrelationID(ctx context.Context) context.Context {
+	return withCorrelationID(ctx, newCorrelationID())
+}
@@ -0,0 +1,81 @@
+package propagation
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryServerInterceptor extracts the correlation ID from incoming gRPC
+// metadata, or generates one if absent, injects it into baggage and the
+// active span, and re-emits it on the outgoing response header, mirroring
+// HTTPMiddleware for gRPC hops.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, id := withIncomingCorrelationID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(CorrelationIDHeader, id))
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the streaming equivalent of UnaryServerInterceptor.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withIncomingCorrelationID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(CorrelationIDHeader, id))
+		wrapped := &correlatedServerStream{
+			ServerStream: ss,
+			ctx:          ctx,
+		}
+		return handler(srv, wrapped)
+	}
+}
+
+// UnaryClientInterceptor re-emits the correlation ID carried in ctx's
+// baggage as outgoing gRPC metadata, generating one if the call has none.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withOutgoingCorrelationID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor is the streaming equivalent of UnaryClientInterceptor.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withOutgoingCorrelationID(ctx), desc, cc, method, opts...)
+	}
+}
+
+func withIncomingCorrelationID(ctx context.Context) (context.Context, string) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(CorrelationIDHeader); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if id == "" {
+		id = newCorrelationID()
+	}
+	return withCorrelationID(ctx, id), id
+}
+
+func withOutgoingCorrelationID(ctx context.Context) context.Context {
+	id := CorrelationIDFromContext(ctx)
+	if id == "" {
+		id = newCorrelationID()
+		ctx = withCorrelationID(ctx, id)
+	}
+	return metadata.AppendToOutgoingContext(ctx, CorrelationIDHeader, id)
+}
+
+// correlatedServerStream overrides Context so handlers observe the
+// correlation-ID-enriched context instead of the raw stream context.
+type correlatedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *correlatedServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,24 @@
+package propagation
+
+import "net/http"
+
+// HTTPMiddleware extracts X-Correlation-ID from the incoming request (falling
+// back to the correlation ID in the request context's baggage), or generates
+// one if neither is present, injects it into baggage and the active span, and
+// re-emits it on the response so a client can correlate its own request.
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(CorrelationIDHeader)
+		if id == "" {
+			id = CorrelationIDFromContext(r.Context())
+		}
+		if id == "" {
+			id = newCorrelationID()
+		}
+
+		ctx := withCorrelationID(r.Context(), id)
+		w.Header().Set(CorrelationIDHeader, id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,35 @@
+// Package propagation configures a composite TextMapPropagator (W3C
+// tracecontext + baggage, optionally B3) and provides HTTP middleware and
+// gRPC interceptors that carry a correlation ID across mixed HTTP+gRPC hops.
+package propagation
+
+import (
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// CorrelationIDHeader is the HTTP header and gRPC metadata key used to carry
+// the correlation ID.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// correlationIDSpanKey is the span attribute the correlation ID is recorded
+// under.
+const correlationIDSpanKey = "correlation_id"
+
+// Init builds a composite TextMapPropagator and installs it as the global
+// propagator. includeB3 additionally accepts/emits single-header B3
+// (common when one hop in the call graph is still on Zipkin-style tracing).
+func Init(includeB3 bool) propagation.TextMapPropagator {
+	propagators := []propagation.TextMapPropagator{
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	}
+	if includeB3 {
+		propagators = append(propagators, b3.New())
+	}
+
+	composite := propagation.NewCompositeTextMapPropagator(propagators...)
+	otel.SetTextMapPropagator(composite)
+	return composite
+}
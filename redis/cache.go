@@ -0,0 +1,165 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+// TracedClient is a CacheInterface implementation backed by go-redis, with
+// every operation wrapped in a span and values serialized as JSON.
+type TracedClient struct {
+	client *redis.Client
+	tracer trace.Tracer
+
+	// TTLJitterPercent adds +/-N% jitter to every TTL passed to SetToCache,
+	// so a fleet of identical keys set at the same time doesn't expire and
+	// get re-populated all at once (cache stampede).
+	TTLJitterPercent int
+
+	group singleflight.Group
+}
+
+// NewTracedClient wraps client for use as a CacheInterface.
+func NewTracedClient(client *redis.Client, serviceName string) *TracedClient {
+	return &TracedClient{
+		client: client,
+		tracer: otel.Tracer(serviceName),
+	}
+}
+
+var _ CacheInterface = (*TracedClient)(nil)
+
+func (c *TracedClient) GetFromCache(ctx context.Context, key string, target any) error {
+	ctx, span := c.tracer.Start(ctx, "cache.Get", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer span.End()
+
+	raw, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		span.SetAttributes(attribute.Bool("cache.hit", false))
+		return err
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.Bool("cache.hit", true),
+		attribute.Int("cache.value_bytes", len(raw)),
+	)
+
+	if err := json.Unmarshal(raw, target); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (c *TracedClient) SetToCache(ctx context.Context, key string, value any, expiration time.Duration) error {
+	ctx, span := c.tracer.Start(ctx, "cache.Set", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer span.End()
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	ttl := withJitter(expiration, c.TTLJitterPercent)
+	span.SetAttributes(
+		attribute.Int("cache.value_bytes", len(raw)),
+		attribute.Int64("cache.ttl_ms", ttl.Milliseconds()),
+	)
+
+	if err := c.client.Set(ctx, key, raw, ttl).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (c *TracedClient) DeleteFromCache(ctx context.Context, key string) error {
+	ctx, span := c.tracer.Start(ctx, "cache.Delete", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer span.End()
+
+	if err := c.client.Del(ctx, key).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func (c *TracedClient) Client() *redis.Client {
+	return c.client
+}
+
+func (c *TracedClient) Close() error {
+	return c.client.Close()
+}
+
+// GetOrLoad reads key into target, calling loader to populate the cache on a
+// miss. Concurrent misses for the same key collapse into a single loader
+// invocation via singleflight; callers that wait for an in-flight load get a
+// child span recording the wait.
+func (c *TracedClient) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) (any, error), target any) error {
+	err := c.GetFromCache(ctx, key, target)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return err
+	}
+
+	ctx, span := c.tracer.Start(ctx, "cache.Load", trace.WithAttributes(attribute.String("cache.key", key)))
+	defer span.End()
+
+	raw, err, shared := c.group.Do(key, func() (any, error) {
+		return loader(ctx)
+	})
+	span.SetAttributes(attribute.Bool("cache.load_shared", shared))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	// SetToCache is idempotent, so every caller - not just the one that ran
+	// loader - repopulates the cache. singleflight's shared flag means "at
+	// least one other caller joined this call", true for every joiner
+	// including the executor, so it can't be used to pick a single leader.
+	if setErr := c.SetToCache(ctx, key, raw, ttl); setErr != nil {
+		span.RecordError(setErr)
+	}
+
+	marshalled, err := json.Marshal(raw)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return json.Unmarshal(marshalled, target)
+}
+
+func withJitter(ttl time.Duration, percent int) time.Duration {
+	if percent <= 0 || ttl <= 0 {
+		return ttl
+	}
+	spread := float64(ttl) * float64(percent) / 100
+	delta := (rand.Float64()*2 - 1) * spread
+	return ttl + time.Duration(delta)
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Mode selects which kind of redis.UniversalClient NewTracedRedisUniversalClient
+// builds.
+type Mode string
+
+const (
+	ModeStandalone Mode = "standalone"
+	ModeSentinel   Mode = "sentinel"
+	ModeCluster    Mode = "cluster"
+)
+
+// RedisConfig is a driver-agnostic description of a redis deployment. It
+// covers standalone, sentinel and cluster topologies so callers can switch
+// between them purely through configuration.
+type RedisConfig struct {
+	Mode       Mode
+	Addrs      []string
+	MasterName string // required when Mode is ModeSentinel
+	Username   string
+	Password   string
+	DB         int
+	TLSConfig  *tls.Config
+
+	// Pool settings, applied regardless of Mode.
+	PoolSize     int
+	MinIdleConns int
+}
+
+// ParseRedisURL parses a redis:// or rediss:// URL into a standalone
+// RedisConfig, so a deployment can be configured entirely from a single
+// environment variable.
+func ParseRedisURL(rawURL string) (RedisConfig, error) {
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return RedisConfig{}, fmt.Errorf("parse redis url: %w", err)
+	}
+
+	return RedisConfig{
+		Mode:         ModeStandalone,
+		Addrs:        []string{opts.Addr},
+		Username:     opts.Username,
+		Password:     opts.Password,
+		DB:           opts.DB,
+		TLSConfig:    opts.TLSConfig,
+		PoolSize:     opts.PoolSize,
+		MinIdleConns: opts.MinIdleConns,
+	}, nil
+}
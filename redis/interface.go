@@ -1,4 +1,4 @@
-package oteltracingredis
+package redis
 
 import (
 	"context"
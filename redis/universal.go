@@ -0,0 +1,66 @@
+package redis
+
+import (
+	"fmt"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewTracedRedisUniversalClient builds the redis.UniversalClient matching
+// cfg.Mode (standalone, sentinel, or cluster) and instruments it with
+// redisotel tracing and metrics, so callers get one return type regardless
+// of topology.
+func NewTracedRedisUniversalClient(cfg RedisConfig) (redis.UniversalClient, error) {
+	var client redis.UniversalClient
+
+	switch cfg.Mode {
+	case "", ModeStandalone:
+		if len(cfg.Addrs) != 1 {
+			return nil, fmt.Errorf("redis: standalone mode requires exactly one addr, got %d", len(cfg.Addrs))
+		}
+		client = redis.NewClient(&redis.Options{
+			Addr:         cfg.Addrs[0],
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			TLSConfig:    cfg.TLSConfig,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+		})
+	case ModeSentinel:
+		if cfg.MasterName == "" {
+			return nil, fmt.Errorf("redis: sentinel mode requires MasterName")
+		}
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:    cfg.MasterName,
+			SentinelAddrs: cfg.Addrs,
+			Username:      cfg.Username,
+			Password:      cfg.Password,
+			DB:            cfg.DB,
+			TLSConfig:     cfg.TLSConfig,
+			PoolSize:      cfg.PoolSize,
+			MinIdleConns:  cfg.MinIdleConns,
+		})
+	case ModeCluster:
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.Addrs,
+			Username:     cfg.Username,
+			Password:     cfg.Password,
+			TLSConfig:    cfg.TLSConfig,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+		})
+	default:
+		return nil, fmt.Errorf("redis: unknown mode %q", cfg.Mode)
+	}
+
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		return nil, fmt.Errorf("instrument tracing: %w", err)
+	}
+	if err := redisotel.InstrumentMetrics(client); err != nil {
+		return nil, fmt.Errorf("instrument metrics: %w", err)
+	}
+
+	return client, nil
+}